@@ -0,0 +1,248 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// State is a node in the reminder's Pomodoro state machine.
+type State int
+
+const (
+	StateWork State = iota
+	StateShortBreak
+	StateLongBreak
+	StateDayComplete
+)
+
+func (s State) String() string {
+	switch s {
+	case StateWork:
+		return "Work"
+	case StateShortBreak:
+		return "ShortBreak"
+	case StateLongBreak:
+		return "LongBreak"
+	case StateDayComplete:
+		return "DayComplete"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event drives a transition in the reminder's state machine.
+type Event int
+
+const (
+	EventTick Event = iota
+	EventComplete
+	EventSkip
+	EventPause
+	EventResume
+	EventReset
+)
+
+func (e Event) String() string {
+	switch e {
+	case EventTick:
+		return "Tick"
+	case EventComplete:
+		return "Complete"
+	case EventSkip:
+		return "Skip"
+	case EventPause:
+		return "Pause"
+	case EventResume:
+		return "Resume"
+	case EventReset:
+		return "Reset"
+	default:
+		return "Unknown"
+	}
+}
+
+// HandlerFunc runs the side effects for one (State, Event) pair and returns
+// the state the machine should move to. Handlers are synchronous so tests
+// can drive the machine by calling Fire directly, without wall-clock sleeps.
+type HandlerFunc func(mr *MoveReminder, event Event) State
+
+// transitions is the FSM's table of handlers, keyed first by the state the
+// event is received in, then by the event itself. Missing entries are
+// treated as no-ops that stay in the current state.
+var transitions = map[State]map[Event]HandlerFunc{
+	StateWork: {
+		EventTick:     handleWorkTick,
+		EventComplete: handleWorkComplete,
+		EventPause:    handlePause,
+		EventResume:   handleResume,
+		EventReset:    handleReset,
+	},
+	StateShortBreak: {
+		EventTick:     handleBreakTick,
+		EventComplete: handleBreakComplete,
+		EventSkip:     handleBreakSkip,
+		EventReset:    handleReset,
+	},
+	StateLongBreak: {
+		EventTick:     handleBreakTick,
+		EventComplete: handleBreakComplete,
+		EventSkip:     handleBreakSkip,
+		EventReset:    handleReset,
+	},
+	StateDayComplete: {
+		EventReset: handleReset,
+	},
+}
+
+// MoveReminder is the Pomodoro state machine driving the work/break cycle.
+// The main loop feeds it Tick and Complete events; the break window feeds it
+// Skip over the control socket.
+type MoveReminder struct {
+	mu sync.Mutex
+
+	state State
+	run   int // monotonic count of completed work sessions; run%runsPerLongBreak==0 triggers a long break
+	today int // completed work sessions today, compared against dailyCap
+
+	paused           bool
+	remainingSeconds int
+	workTicker       *time.Ticker
+	hibernateTimer   *time.Timer
+
+	logger        Logger
+	controlSocket string // passed to the break subprocess so it can report a skip back to us
+}
+
+// NewMoveReminder returns a reminder in its initial Work state, logging
+// through the default slog-backed Logger until SetLogger overrides it.
+func NewMoveReminder() *MoveReminder {
+	return &MoveReminder{state: StateWork, logger: newSlogLogger()}
+}
+
+// SetLogger replaces the reminder's logger, e.g. with a null logger in
+// tests or to silence per-tick logging.
+func (mr *MoveReminder) SetLogger(logger Logger) {
+	mr.logger = logger
+}
+
+// State returns the reminder's current state. Safe for concurrent use by the
+// GUI or an IPC surface.
+func (mr *MoveReminder) State() State {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+	return mr.state
+}
+
+// Fire applies event to the machine and returns the resulting state. It is
+// the only way callers should mutate mr.state.
+func (mr *MoveReminder) Fire(event Event) State {
+	mr.mu.Lock()
+	handlers, ok := transitions[mr.state]
+	if !ok {
+		mr.mu.Unlock()
+		return mr.State()
+	}
+	handler, ok := handlers[event]
+	mr.mu.Unlock()
+	if !ok {
+		return mr.State()
+	}
+
+	next := handler(mr, event)
+
+	mr.mu.Lock()
+	changed := mr.state != next
+	from := mr.state
+	logger := mr.logger
+	mr.state = next
+	mr.mu.Unlock()
+
+	if changed {
+		logger.Info("State transition", "from", from, "event", event, "to", next)
+	}
+	return next
+}
+
+func handleWorkTick(mr *MoveReminder, _ Event) State {
+	mr.mu.Lock()
+	paused := mr.paused
+	remaining := mr.remainingSeconds
+	logger := mr.logger
+	mr.mu.Unlock()
+
+	if paused {
+		return StateWork
+	}
+
+	// Only log every 10 seconds to avoid spamming, unless verbose is set.
+	if !verbose && remaining%10 != 0 {
+		return StateWork
+	}
+
+	minutes := remaining / 60
+	seconds := remaining % 60
+	logger.Info("Work time remaining", "time", fmt.Sprintf("%02d:%02d", minutes, seconds))
+	return StateWork
+}
+
+func handleWorkComplete(mr *MoveReminder, _ Event) State {
+	mr.mu.Lock()
+	mr.run++
+	mr.today++
+	run, today := mr.run, mr.today
+	logger := mr.logger
+	mr.mu.Unlock()
+
+	if dailyCap > 0 && today >= dailyCap {
+		logger.Info("Daily cap reached, hibernating until tomorrow", "completed", today, "cap", dailyCap)
+		return StateDayComplete
+	}
+
+	if runsPerLongBreak > 0 && run%runsPerLongBreak == 0 {
+		logger.Info("Work interval completed - long break time!", "run", run)
+		return StateLongBreak
+	}
+
+	logger.Info("Work interval completed - break time!", "run", run)
+	return StateShortBreak
+}
+
+func handleBreakTick(mr *MoveReminder, _ Event) State {
+	return mr.State() // no-op: the break window owns its own countdown
+}
+
+func handleBreakComplete(_ *MoveReminder, _ Event) State {
+	return StateWork
+}
+
+func handleBreakSkip(mr *MoveReminder, _ Event) State {
+	mr.logger.Info("Break skipped, resuming work")
+	return StateWork
+}
+
+func handlePause(mr *MoveReminder, _ Event) State {
+	mr.mu.Lock()
+	mr.paused = true
+	mr.mu.Unlock()
+	mr.logger.Info("Work timer paused")
+	return StateWork
+}
+
+func handleResume(mr *MoveReminder, _ Event) State {
+	mr.mu.Lock()
+	mr.paused = false
+	mr.mu.Unlock()
+	mr.logger.Info("Work timer resumed")
+	return StateWork
+}
+
+func handleReset(mr *MoveReminder, _ Event) State {
+	mr.mu.Lock()
+	mr.run = 0
+	mr.today = 0
+	mr.paused = false
+	mr.mu.Unlock()
+	mr.logger.Info("Reminder reset")
+	return StateWork
+}
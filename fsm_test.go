@@ -0,0 +1,109 @@
+package main
+
+import "testing"
+
+// withLongBreakCadence and withDailyCap save/restore the package-level
+// config vars the handlers read, so tests can drive them deterministically
+// without touching flag parsing.
+func withLongBreakCadence(t *testing.T, runs int) {
+	t.Helper()
+	old := runsPerLongBreak
+	runsPerLongBreak = runs
+	t.Cleanup(func() { runsPerLongBreak = old })
+}
+
+func withDailyCap(t *testing.T, cap int) {
+	t.Helper()
+	old := dailyCap
+	dailyCap = cap
+	t.Cleanup(func() { dailyCap = old })
+}
+
+func newTestReminder() *MoveReminder {
+	mr := NewMoveReminder()
+	mr.SetLogger(nullLogger{})
+	return mr
+}
+
+func TestFireWorkComplete(t *testing.T) {
+	withLongBreakCadence(t, 0) // disabled, so every completion is a short break
+
+	mr := newTestReminder()
+	got := mr.Fire(EventComplete)
+	if got != StateShortBreak {
+		t.Fatalf("Fire(EventComplete) from Work = %v, want %v", got, StateShortBreak)
+	}
+	if mr.run != 1 {
+		t.Fatalf("run = %d, want 1", mr.run)
+	}
+}
+
+func TestFireLongBreakCadence(t *testing.T) {
+	withLongBreakCadence(t, 2)
+
+	table := []struct {
+		completions int
+		want        State
+	}{
+		{1, StateShortBreak},
+		{2, StateLongBreak},
+		{3, StateShortBreak},
+		{4, StateLongBreak},
+	}
+
+	mr := newTestReminder()
+	var got State
+	for i, tt := range table {
+		mr.state = StateWork // each completion starts fresh from Work
+		got = mr.Fire(EventComplete)
+		if got != tt.want {
+			t.Fatalf("completion %d (run=%d): Fire(EventComplete) = %v, want %v", i+1, tt.completions, got, tt.want)
+		}
+	}
+}
+
+func TestFireDailyCapThenReset(t *testing.T) {
+	withLongBreakCadence(t, 0)
+	withDailyCap(t, 1)
+
+	mr := newTestReminder()
+	if got := mr.Fire(EventComplete); got != StateDayComplete {
+		t.Fatalf("Fire(EventComplete) at the cap = %v, want %v", got, StateDayComplete)
+	}
+	if mr.today != 1 {
+		t.Fatalf("today = %d, want 1", mr.today)
+	}
+
+	// Simulate the next day's reset. Without clearing today, the very next
+	// completion would immediately re-enter DayComplete.
+	if got := mr.Fire(EventReset); got != StateWork {
+		t.Fatalf("Fire(EventReset) from DayComplete = %v, want %v", got, StateWork)
+	}
+	if mr.today != 0 {
+		t.Fatalf("today after reset = %d, want 0", mr.today)
+	}
+
+	if got := mr.Fire(EventComplete); got != StateDayComplete {
+		t.Fatalf("Fire(EventComplete) on the new day = %v, want %v", got, StateDayComplete)
+	}
+}
+
+func TestFireUnhandledEventIsNoop(t *testing.T) {
+	mr := newTestReminder()
+	got := mr.Fire(EventSkip) // Skip has no handler in StateWork
+	if got != StateWork {
+		t.Fatalf("Fire(EventSkip) from Work = %v, want %v (no-op)", got, StateWork)
+	}
+}
+
+func TestFirePauseResume(t *testing.T) {
+	mr := newTestReminder()
+	mr.Fire(EventPause)
+	if !mr.paused {
+		t.Fatal("paused = false after Fire(EventPause)")
+	}
+	mr.Fire(EventResume)
+	if mr.paused {
+		t.Fatal("paused = true after Fire(EventResume)")
+	}
+}
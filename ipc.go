@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// controlCommand is the newline-delimited JSON a client sends to the
+// control socket: {"cmd":"pause"}, "resume", "skip", "status", or "reset".
+type controlCommand struct {
+	Cmd string `json:"cmd"`
+}
+
+// controlResponse reports the reminder's state after a command is applied.
+type controlResponse struct {
+	State string `json:"state"`
+	Run   int    `json:"run"`
+	Today int    `json:"today"`
+	Error string `json:"error,omitempty"`
+}
+
+// defaultControlSocketPath returns $XDG_RUNTIME_DIR/move.sock, falling back
+// to a path under os.TempDir when XDG_RUNTIME_DIR isn't set.
+func defaultControlSocketPath() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "move.sock")
+}
+
+// controlServer listens on a Unix domain socket and applies incoming
+// commands to a MoveReminder, so users can bind global hotkeys or menu-bar
+// apps to pause/resume/skip/status/reset without a GUI, and so the break
+// window can report a skip back to the parent instead of via exit code.
+type controlServer struct {
+	mr       *MoveReminder
+	listener net.Listener
+}
+
+func newControlServer(mr *MoveReminder, socketPath string) (*controlServer, error) {
+	os.Remove(socketPath) // clear a stale socket left behind by a crash
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("listen on control socket: %w", err)
+	}
+	return &controlServer{mr: mr, listener: listener}, nil
+}
+
+func (s *controlServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *controlServer) close() {
+	s.listener.Close()
+}
+
+func (s *controlServer) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	encoder := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		var cmd controlCommand
+		if err := json.Unmarshal(scanner.Bytes(), &cmd); err != nil {
+			encoder.Encode(controlResponse{Error: err.Error()})
+			continue
+		}
+		encoder.Encode(s.apply(cmd.Cmd))
+	}
+}
+
+func (s *controlServer) apply(cmd string) controlResponse {
+	switch cmd {
+	case "pause":
+		s.mr.Fire(EventPause)
+	case "resume":
+		s.mr.Fire(EventResume)
+	case "skip":
+		s.mr.Fire(EventSkip)
+	case "reset":
+		wasHibernating := s.mr.State() == StateDayComplete
+		s.mr.Fire(EventReset)
+		// Coming out of DayComplete, the work ticker goroutine has already
+		// exited; restart it instead of leaving the reminder idle in Work.
+		if wasHibernating {
+			s.mr.scheduleNext()
+		}
+	case "status":
+		// Report current state without firing a transition.
+	default:
+		return controlResponse{Error: fmt.Sprintf("unknown command %q", cmd)}
+	}
+
+	s.mr.mu.Lock()
+	run, today := s.mr.run, s.mr.today
+	s.mr.mu.Unlock()
+	return controlResponse{State: s.mr.State().String(), Run: run, Today: today}
+}
+
+// sendControlCommand dials socketPath, sends cmd, and returns the parsed
+// response. Used by both the `move ctl` subcommand and the break window
+// reporting a skip back to the parent.
+func sendControlCommand(socketPath, cmd string) (controlResponse, error) {
+	conn, err := net.DialTimeout("unix", socketPath, 2*time.Second)
+	if err != nil {
+		return controlResponse{}, err
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(controlCommand{Cmd: cmd}); err != nil {
+		return controlResponse{}, err
+	}
+
+	var resp controlResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return controlResponse{}, err
+	}
+	if resp.Error != "" {
+		return resp, fmt.Errorf("%s", resp.Error)
+	}
+	return resp, nil
+}
+
+// runCtl implements the `move ctl <cmd>` subcommand: it dials the control
+// socket, sends one command, and prints the resulting state.
+func runCtl(args []string) {
+	fs := flag.NewFlagSet("ctl", flag.ExitOnError)
+	socketFlag := fs.String("control-socket", defaultControlSocketPath(), "Path to the control socket")
+	fs.Parse(args)
+
+	rest := fs.Args()
+	if len(rest) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: move ctl <pause|resume|skip|status|reset>")
+		os.Exit(2)
+	}
+
+	resp, err := sendControlCommand(*socketFlag, rest[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "move ctl:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("state=%s run=%d today=%d\n", resp.State, resp.Run, resp.Today)
+}
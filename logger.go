@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger is how MoveReminder and BreakWindow log, instead of calling
+// package-level slog directly. Injecting a null logger silences the
+// once-every-10-seconds "Work time remaining" spam, and tests can assert on a fake
+// implementation without wall-clock output.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+	Fatal(msg string, args ...any)
+}
+
+// slogLogger is the default backend. It wraps slog and flushes stdout after
+// every call, taking over the job scattered os.Stdout.Sync() calls used to do.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func newSlogLogger() *slogLogger {
+	return &slogLogger{logger: slog.Default()}
+}
+
+func (l *slogLogger) Debug(msg string, args ...any) {
+	l.logger.Debug(msg, args...)
+	os.Stdout.Sync()
+}
+
+func (l *slogLogger) Info(msg string, args ...any) {
+	l.logger.Info(msg, args...)
+	os.Stdout.Sync()
+}
+
+func (l *slogLogger) Warn(msg string, args ...any) {
+	l.logger.Warn(msg, args...)
+	os.Stdout.Sync()
+}
+
+func (l *slogLogger) Error(msg string, args ...any) {
+	l.logger.Error(msg, args...)
+	os.Stdout.Sync()
+}
+
+func (l *slogLogger) Fatal(msg string, args ...any) {
+	l.logger.Error(msg, args...)
+	os.Stdout.Sync()
+	os.Exit(1)
+}
+
+// nullLogger discards everything.
+type nullLogger struct{}
+
+func (nullLogger) Debug(string, ...any) {}
+func (nullLogger) Info(string, ...any)  {}
+func (nullLogger) Warn(string, ...any)  {}
+func (nullLogger) Error(string, ...any) {}
+func (nullLogger) Fatal(string, ...any) { os.Exit(1) }
+
+const (
+	logFileSizeCap     = 5 * 1024 * 1024
+	logFileIdleTimeout = 5 * time.Minute
+)
+
+// fileLogger writes plain key=value log lines to path, rotating to a .1
+// suffix once the file passes logFileSizeCap. The idle check runs only at
+// the start of a write: if more than logFileIdleTimeout has passed since the
+// last one, the handle is closed and the file reopened. Nothing closes the
+// handle while the logger stays idle.
+type fileLogger struct {
+	mu   sync.Mutex
+	path string
+
+	file     *os.File
+	size     int64
+	lastUsed time.Time
+}
+
+func newFileLogger(path string) *fileLogger {
+	return &fileLogger{path: path}
+}
+
+func (fl *fileLogger) write(level, msg string, args []any) {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+
+	if fl.file != nil && time.Since(fl.lastUsed) > logFileIdleTimeout {
+		fl.file.Close()
+		fl.file = nil
+	}
+	if fl.file == nil {
+		f, err := os.OpenFile(fl.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			slog.Error("Failed to open log file", "path", fl.path, "error", err)
+			return
+		}
+		fl.file = f
+		fl.size = 0
+		if info, err := f.Stat(); err == nil {
+			fl.size = info.Size()
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(time.Now().Format(time.RFC3339))
+	b.WriteString(" level=")
+	b.WriteString(level)
+	b.WriteString(" msg=")
+	b.WriteString(fmt.Sprintf("%q", msg))
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	b.WriteByte('\n')
+	line := b.String()
+
+	if fl.size+int64(len(line)) > logFileSizeCap {
+		fl.rotateLocked()
+	}
+
+	n, err := fl.file.WriteString(line)
+	if err != nil {
+		slog.Error("Failed to write log file", "path", fl.path, "error", err)
+		return
+	}
+	fl.size += int64(n)
+	fl.lastUsed = time.Now()
+}
+
+func (fl *fileLogger) rotateLocked() {
+	fl.file.Close()
+	os.Rename(fl.path, fl.path+".1")
+
+	f, err := os.OpenFile(fl.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		slog.Error("Failed to rotate log file", "path", fl.path, "error", err)
+		fl.file = nil
+		return
+	}
+	fl.file = f
+	fl.size = 0
+}
+
+func (fl *fileLogger) Debug(msg string, args ...any) { fl.write("DEBUG", msg, args) }
+func (fl *fileLogger) Info(msg string, args ...any)  { fl.write("INFO", msg, args) }
+func (fl *fileLogger) Warn(msg string, args ...any)  { fl.write("WARN", msg, args) }
+func (fl *fileLogger) Error(msg string, args ...any) { fl.write("ERROR", msg, args) }
+func (fl *fileLogger) Fatal(msg string, args ...any) {
+	fl.write("FATAL", msg, args)
+	os.Exit(1)
+}
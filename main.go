@@ -3,10 +3,10 @@ package main
 import (
 	"flag"
 	"fmt"
-	"log/slog"
 	"os"
 	"os/exec"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -14,82 +14,153 @@ import (
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/widget"
+
+	"github.com/sluipmoord/move/notifier"
 )
 
 var (
-	workInterval  time.Duration
-	breakDuration time.Duration
-	verbose       bool
+	workInterval       time.Duration
+	shortBreakDuration time.Duration
+	longBreakDuration  time.Duration
+	runsPerLongBreak   int
+	dailyCap           int
+	verbose            bool
 )
 
-type MoveReminder struct {
-	workEnd    time.Time
-	workTicker *time.Ticker
-}
-
-func NewMoveReminder() *MoveReminder {
-	return &MoveReminder{}
+// breakDurationFor returns how long the upcoming break window should run for,
+// based on which break state the reminder just transitioned into.
+func breakDurationFor(state State) time.Duration {
+	if state == StateLongBreak {
+		return longBreakDuration
+	}
+	return shortBreakDuration
 }
 
-func (mr *MoveReminder) showBreakWindow() {
-	// Show system notification
-	mr.showNotification()
-
-	// Launch separate GUI process for break window
-	cmd := exec.Command(os.Args[0], "-break-mode", fmt.Sprintf("-break-duration=%s", breakDuration))
-	err := cmd.Start()
-	if err != nil {
-		slog.Error("Failed to start break window process", "error", err)
+func (mr *MoveReminder) showBreakWindow(state State) {
+	if state == StateDayComplete {
+		mr.hibernateUntilTomorrow()
 		return
 	}
 
-	// Wait for break process to complete
-	err = cmd.Wait()
-	if err != nil {
-		slog.Info("Break process ended", "error", err)
+	// Show system notification
+	if err := notifier.Default.Notify("Move Break Time!", "Stand up, stretch, and move around. Take a break from your computer!"); err != nil {
+		mr.logger.Error("Failed to show notification", "error", err)
 	}
 
-	slog.Info("Break completed, resuming work")
+	mr.runBreakSubprocess(breakDurationFor(state))
+
+	// A skipped break already moved us back to Work via the control
+	// socket; only fire Complete here for a break that ran its course.
+	if mr.State() != StateWork {
+		mr.Fire(EventComplete)
+	}
 	mr.scheduleNext()
 }
 
-func (mr *MoveReminder) showNotification() {
-	// Show system notification on macOS
-	title := "Move Break Time!"
-	message := "Stand up, stretch, and move around. Take a break from your computer!"
-	cmd := exec.Command("osascript", "-e", fmt.Sprintf(`display notification "%s" with title "%s"`, message, title))
-	err := cmd.Run()
-	if err != nil {
-		slog.Error("Failed to show notification", "error", err)
+// maxBreakRestarts bounds how many times a crashed break window is
+// relaunched before we give up and just resume work.
+const maxBreakRestarts = 3
+
+// runBreakSubprocess launches the break window and waits for it to exit. If
+// it exits cleanly the break is over. If it crashes mid-break (a Fyne panic,
+// for example), it's relaunched with whatever time was left rather than
+// jumping straight back to work.
+func (mr *MoveReminder) runBreakSubprocess(duration time.Duration) {
+	remaining := duration
+
+	for attempt := 0; attempt <= maxBreakRestarts; attempt++ {
+		cmd := exec.Command(os.Args[0], "-break-mode",
+			fmt.Sprintf("-break-duration=%s", duration),
+			fmt.Sprintf("-break-remaining=%s", remaining),
+			fmt.Sprintf("-control-socket=%s", mr.controlSocket))
+
+		started := time.Now()
+		if err := cmd.Start(); err != nil {
+			mr.logger.Error("Failed to start break window process", "error", err)
+			return
+		}
+
+		err := cmd.Wait()
+		remaining -= time.Since(started)
+
+		if err == nil {
+			mr.logger.Info("Break completed, resuming work")
+			return
+		}
+		if remaining <= 0 {
+			mr.logger.Warn("Break window exited unexpectedly but the break was already over", "error", err)
+			return
+		}
+
+		mr.logger.Warn("Break window crashed mid-break, relaunching with remaining time", "error", err, "remaining", remaining, "attempt", attempt+1)
 	}
+
+	mr.logger.Error("Break window kept crashing, giving up and resuming work", "max_restarts", maxBreakRestarts)
 }
 
-func (mr *MoveReminder) startWorkTimer() {
-	mr.workEnd = time.Now().Add(workInterval)
+// hibernateUntilTomorrow keeps the process alive but idle once the daily cap
+// has been hit, waking the reminder at the next local midnight.
+func (mr *MoveReminder) hibernateUntilTomorrow() {
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	mr.logger.Info("Daily cap reached, sleeping until tomorrow", "wake_at", midnight)
 
-	// Use different intervals based on verbose flag
-	interval := 10 * time.Second
-	if verbose {
-		interval = 1 * time.Second
+	timer := time.AfterFunc(time.Until(midnight), func() {
+		mr.Fire(EventReset)
+		mr.scheduleNext()
+	})
+
+	mr.mu.Lock()
+	mr.hibernateTimer = timer
+	mr.mu.Unlock()
+}
+
+// cancelHibernation stops a pending midnight wake-up timer, if any. It must
+// run before a work timer is (re)started, otherwise a manual reset out of
+// DayComplete (e.g. via the control socket) leaves the original midnight
+// timer armed; it would later fire its own EventReset/scheduleNext and start
+// a second work ticker alongside this one.
+func (mr *MoveReminder) cancelHibernation() {
+	mr.mu.Lock()
+	timer := mr.hibernateTimer
+	mr.hibernateTimer = nil
+	mr.mu.Unlock()
+
+	if timer != nil {
+		timer.Stop()
 	}
+}
+
+// startWorkTimer decrements a plain integer once per tick of a single
+// ticker rather than comparing against a wall-clock deadline. A single
+// source of truth for "how long is left" keeps pause/resume trivial (just
+// stop decrementing) and is what lets the FSM's state be serialized later.
+func (mr *MoveReminder) startWorkTimer() {
+	mr.mu.Lock()
+	mr.remainingSeconds = int(workInterval.Seconds())
+	mr.mu.Unlock()
 
-	mr.workTicker = time.NewTicker(interval)
+	mr.workTicker = time.NewTicker(time.Second)
 
 	go func() {
 		for range mr.workTicker.C {
-			remaining := time.Until(mr.workEnd)
+			mr.mu.Lock()
+			if mr.paused {
+				mr.mu.Unlock()
+				continue
+			}
+			mr.remainingSeconds--
+			remaining := mr.remainingSeconds
+			mr.mu.Unlock()
+
 			if remaining <= 0 {
 				mr.workTicker.Stop()
-				slog.Info("Work interval completed - break time!")
-				os.Stdout.Sync() // Force flush
-				mr.showBreakWindow()
+				next := mr.Fire(EventComplete)
+				mr.showBreakWindow(next)
 				return
 			}
 
-			minutes := int(remaining.Minutes())
-			seconds := int(remaining.Seconds()) % 60
-			slog.Info("Work time remaining", "time", fmt.Sprintf("%02d:%02d", minutes, seconds))
-			os.Stdout.Sync() // Force flush after each log
+			mr.Fire(EventTick)
 		}
 	}()
 }
@@ -101,13 +172,13 @@ func (mr *MoveReminder) stopWorkTimer() {
 }
 
 func (mr *MoveReminder) scheduleNext() {
-	slog.Info("Starting work interval", "duration", workInterval)
-	os.Stdout.Sync()
+	mr.cancelHibernation()
+	mr.logger.Info("Starting work interval", "duration", workInterval)
 	mr.startWorkTimer()
 }
 
 func (mr *MoveReminder) start() {
-	slog.Info("Move reminder started", "work_interval", workInterval, "break_duration", breakDuration)
+	mr.logger.Info("Move reminder started", "work_interval", workInterval, "short_break", shortBreakDuration, "long_break", longBreakDuration)
 	mr.scheduleNext()
 
 	// Keep the main thread alive
@@ -115,40 +186,77 @@ func (mr *MoveReminder) start() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "ctl" {
+		runCtl(os.Args[2:])
+		return
+	}
+
 	workFlag := flag.Duration("work", 25*time.Minute, "Work interval duration (e.g., 25m, 10s)")
-	breakFlag := flag.Duration("break", 5*time.Minute, "Break duration (e.g., 5m, 10s)")
+	shortFlag := flag.Duration("short", 5*time.Minute, "Short break duration (e.g., 5m, 10s)")
+	longFlag := flag.Duration("long", 15*time.Minute, "Long break duration (e.g., 15m, 30s)")
+	runsFlag := flag.Int("runs", 4, "Take a long break every N-th completed work interval (0 disables long breaks)")
+	dayFlag := flag.Int("day", 0, "Daily cap of completed work sessions before hibernating until tomorrow (0 disables the cap)")
 	verboseFlag := flag.Bool("verbose", false, "Enable verbose logging every 1 seconds")
 	breakMode := flag.Bool("break-mode", false, "Run in break window mode (internal use)")
 	breakDurationFlag := flag.Duration("break-duration", 5*time.Minute, "Break duration for break mode")
+	breakRemainingFlag := flag.Duration("break-remaining", 0, "Time left in the break, used when relaunching after a crash (internal use)")
+	superviseFlag := flag.Bool("supervise", false, "Run the reminder under a supervisor that restarts it if it crashes")
+	logFileFlag := flag.String("log-file", "", "Write logs to this rotating file instead of stdout")
+	controlSocketFlag := flag.String("control-socket", defaultControlSocketPath(), "Path to the Unix control socket for pause/resume/skip/status/reset")
 	flag.Parse()
 
 	workInterval = *workFlag
-	breakDuration = *breakFlag
+	shortBreakDuration = *shortFlag
+	longBreakDuration = *longFlag
+	runsPerLongBreak = *runsFlag
+	dailyCap = *dayFlag
 	verbose = *verboseFlag
 
 	// If in break mode, run the GUI break window
 	if *breakMode {
-		runBreakWindow(*breakDurationFlag)
+		duration := *breakDurationFlag
+		if *breakRemainingFlag > 0 {
+			duration = *breakRemainingFlag
+		}
+		breakWindow := NewBreakWindow(duration)
+		if *logFileFlag != "" {
+			breakWindow.SetLogger(newFileLogger(*logFileFlag))
+		}
+		breakWindow.controlSocket = *controlSocketFlag
+		breakWindow.start()
 		return
 	}
 
-	// Force logs to be visible by flushing stdout
-	slog.Info("Move reminder configured", "work_interval", workInterval, "break_duration", breakDuration)
-	os.Stdout.Sync()
+	if *superviseFlag {
+		runSupervised()
+		return
+	}
+
+	reminder := NewMoveReminder()
+	reminder.controlSocket = *controlSocketFlag
+	if *logFileFlag != "" {
+		reminder.SetLogger(newFileLogger(*logFileFlag))
+	}
+
+	reminder.logger.Info("Move reminder configured", "work_interval", workInterval, "short_break", shortBreakDuration, "long_break", longBreakDuration, "runs_per_long_break", runsPerLongBreak, "daily_cap", dailyCap)
 
 	if verbose {
-		slog.Info("Verbose logging enabled - will log every 1 seconds")
-		os.Stdout.Sync()
+		reminder.logger.Info("Verbose logging enabled - will log every 1 seconds")
 	}
 
-	reminder := NewMoveReminder()
+	if server, err := newControlServer(reminder, *controlSocketFlag); err != nil {
+		reminder.logger.Warn("Failed to start control socket", "path", *controlSocketFlag, "error", err)
+	} else {
+		reminder.logger.Info("Control socket listening", "path", *controlSocketFlag)
+		go server.serve()
+	}
 
 	// Handle system signals (Cmd+Q) gracefully
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-sigChan
-		slog.Info("Received quit signal - exiting")
+		reminder.logger.Info("Received quit signal - exiting")
 		os.Exit(0)
 	}()
 
@@ -156,15 +264,20 @@ func main() {
 }
 
 type BreakWindow struct {
-	app         fyne.App
-	window      fyne.Window
-	timerLabel  *widget.Label
-	message     *widget.Label
-	closeButton *widget.Button
-	ticker      *time.Ticker
-	breakEnd    time.Time
-	timerActive bool
-	duration    time.Duration
+	mu sync.Mutex
+
+	app              fyne.App
+	window           fyne.Window
+	timerLabel       *widget.Label
+	message          *widget.Label
+	closeButton      *widget.Button
+	ticker           *time.Ticker
+	remainingSeconds int
+	timerActive      bool
+	duration         time.Duration
+
+	logger        Logger
+	controlSocket string // dialed on skip to tell the parent, instead of relying on our exit code
 }
 
 func NewBreakWindow(duration time.Duration) *BreakWindow {
@@ -174,21 +287,30 @@ func NewBreakWindow(duration time.Duration) *BreakWindow {
 	bw := &BreakWindow{
 		app:      myApp,
 		duration: duration,
+		logger:   newSlogLogger(),
 	}
 
 	return bw
 }
 
+// SetLogger replaces the break window's logger, e.g. with a null logger in
+// tests or to silence focus-maintenance logging.
+func (bw *BreakWindow) SetLogger(logger Logger) {
+	bw.logger = logger
+}
+
 func (bw *BreakWindow) showBreakWindow() {
-	bw.breakEnd = time.Now().Add(bw.duration)
+	bw.remainingSeconds = int(bw.duration.Seconds())
 
 	// Create break window
 	bw.window = bw.app.NewWindow("Move Break")
 
 	// Intercept close attempts - Cmd+Q should always quit
 	bw.window.SetCloseIntercept(func() {
-		slog.Info("Break window close intercepted - quitting")
+		bw.logger.Info("Break window close intercepted - quitting")
+		bw.mu.Lock()
 		bw.timerActive = false
+		bw.mu.Unlock()
 		if bw.ticker != nil {
 			bw.ticker.Stop()
 		}
@@ -218,7 +340,7 @@ func (bw *BreakWindow) showBreakWindow() {
 	// Add keyboard shortcut handler for skip (S key)
 	bw.window.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) {
 		if key.Name == fyne.KeyS {
-			slog.Info("Break skipped via keyboard shortcut (S key)")
+			bw.logger.Info("Break skipped via keyboard shortcut (S key)")
 			bw.skipBreak()
 		}
 	})
@@ -249,16 +371,39 @@ func (bw *BreakWindow) showBreakWindow() {
 }
 
 func (bw *BreakWindow) startTimer() {
+	bw.mu.Lock()
 	bw.timerActive = true
-	bw.updateTimer()
+	bw.mu.Unlock()
+	bw.updateTimer() // render the full duration immediately, before the first tick
+	go bw.runTimer()
 }
 
-func (bw *BreakWindow) updateTimer() {
-	if !bw.timerActive {
-		return // Timer has been stopped
+// runTimer decrements remainingSeconds once per tick of a single ticker.
+// Pausing the break is just a matter of not decrementing; nothing drifts
+// against wall-clock time the way two independent tickers could.
+func (bw *BreakWindow) runTimer() {
+	for range bw.ticker.C {
+		bw.mu.Lock()
+		if !bw.timerActive {
+			bw.mu.Unlock()
+			return
+		}
+		bw.remainingSeconds--
+		remaining := bw.remainingSeconds
+		bw.mu.Unlock()
+
+		bw.updateTimer()
+		if remaining <= 0 {
+			return
+		}
 	}
+}
+
+func (bw *BreakWindow) updateTimer() {
+	bw.mu.Lock()
+	remaining := bw.remainingSeconds
+	bw.mu.Unlock()
 
-	remaining := time.Until(bw.breakEnd)
 	if remaining <= 0 {
 		fyne.Do(func() {
 			bw.message.SetText("Break time is complete!\nYou can now close this window and return to work.")
@@ -269,20 +414,18 @@ func (bw *BreakWindow) updateTimer() {
 		return
 	}
 
-	minutes := int(remaining.Minutes())
-	seconds := int(remaining.Seconds()) % 60
+	minutes := remaining / 60
+	seconds := remaining % 60
 	fyne.Do(func() {
 		bw.timerLabel.SetText(fmt.Sprintf("Time remaining: %02d:%02d", minutes, seconds))
 	})
-
-	if bw.timerActive {
-		time.AfterFunc(time.Second, bw.updateTimer)
-	}
 }
 
 func (bw *BreakWindow) skipBreak() {
-	slog.Info("Skipping break via keyboard shortcut")
+	bw.logger.Info("Skipping break via keyboard shortcut")
+	bw.mu.Lock()
 	bw.timerActive = false
+	bw.mu.Unlock()
 	if bw.ticker != nil {
 		bw.ticker.Stop()
 	}
@@ -292,17 +435,14 @@ func (bw *BreakWindow) skipBreak() {
 		bw.window.Hide()
 	}
 
-	slog.Info("Break skipped, exiting break window")
-	bw.app.Quit()
-}
-
-func (bw *BreakWindow) bringToFront() {
-	// Use AppleScript to bring our app to the front on macOS
-	cmd := exec.Command("osascript", "-e", `tell application "System Events" to set frontmost of first process whose name contains "main" to true`)
-	err := cmd.Run()
-	if err != nil {
-		slog.Error("Failed to bring window to front", "error", err)
+	if bw.controlSocket != "" {
+		if _, err := sendControlCommand(bw.controlSocket, "skip"); err != nil {
+			bw.logger.Warn("Failed to report skip to parent over control socket", "error", err)
+		}
 	}
+
+	bw.logger.Info("Break skipped, exiting break window")
+	bw.app.Quit()
 }
 
 func (bw *BreakWindow) maintainFocus() {
@@ -311,31 +451,30 @@ func (bw *BreakWindow) maintainFocus() {
 	defer focusTicker.Stop()
 
 	for range focusTicker.C {
-		if !bw.timerActive {
-			break
-		}
+		bw.mu.Lock()
+		active, remaining := bw.timerActive, bw.remainingSeconds
+		bw.mu.Unlock()
 
-		remaining := time.Until(bw.breakEnd)
-		if remaining <= 0 {
+		if !active || remaining <= 0 {
 			break
 		}
 
 		// Continuously request focus to keep window active
 		if bw.window != nil {
-			fyne.Do(func() {
-				bw.window.RequestFocus()
-			})
-			// Also try to bring to front via system command
-			bw.bringToFront()
+			if err := notifier.Default.Focus(bw.window); err != nil {
+				bw.logger.Error("Failed to bring window to front", "error", err)
+			}
 		}
 	}
 }
 
 func (bw *BreakWindow) closeBreakWindow() {
-	slog.Info("Closing break window")
+	bw.logger.Info("Closing break window")
 
 	// Stop all timers immediately
+	bw.mu.Lock()
 	bw.timerActive = false
+	bw.mu.Unlock()
 	if bw.ticker != nil {
 		bw.ticker.Stop()
 	}
@@ -348,7 +487,7 @@ func (bw *BreakWindow) closeBreakWindow() {
 		bw.window.Hide()
 	}
 
-	slog.Info("Break window closed, exiting")
+	bw.logger.Info("Break window closed, exiting")
 	bw.app.Quit()
 }
 
@@ -356,8 +495,3 @@ func (bw *BreakWindow) start() {
 	bw.showBreakWindow()
 	bw.app.Run()
 }
-
-func runBreakWindow(duration time.Duration) {
-	breakWindow := NewBreakWindow(duration)
-	breakWindow.start()
-}
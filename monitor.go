@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	superviseMaxRestarts = 5
+	superviseWindow      = 60 * time.Second
+	ringBufferFirstLines = 10
+	ringBufferLastLines  = 50
+)
+
+// ringBuffer keeps the first N and last M lines written to it, so a crash
+// report stays useful without holding an unbounded amount of child output.
+type ringBuffer struct {
+	mu    sync.Mutex
+	first []string
+	last  []string
+	total int
+}
+
+func (r *ringBuffer) writeLine(line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.total++
+	if len(r.first) < ringBufferFirstLines {
+		r.first = append(r.first, line)
+	}
+	r.last = append(r.last, line)
+	if len(r.last) > ringBufferLastLines {
+		r.last = r.last[1:]
+	}
+}
+
+func (r *ringBuffer) dump() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// For a short-lived crash, first and last can cover overlapping lines
+	// (e.g. 5 lines total fits in both the first-10 and last-50 windows).
+	// Trim that overlap off the front of last so it isn't printed twice.
+	last := r.last
+	if overlap := len(r.first) + len(last) - r.total; overlap > 0 {
+		last = last[overlap:]
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Join(r.first, "\n"))
+	if omitted := r.total - len(r.first) - len(r.last); omitted > 0 {
+		b.WriteString(fmt.Sprintf("\n... (%d lines omitted) ...\n", omitted))
+	} else if len(r.first) > 0 && len(last) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Join(last, "\n"))
+	return b.String()
+}
+
+// lineWriter is an io.Writer that reassembles arbitrary Write chunks into
+// complete lines before handing them to a ringBuffer.
+type lineWriter struct {
+	ring *ringBuffer
+	buf  []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.ring.writeLine(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// runSupervised fork/execs move as its own child (without -supervise, so it
+// doesn't recurse), mirrors the child's output to our own stdout/stderr
+// while also capturing it into a ring buffer, and restarts the child if it
+// exits unexpectedly. It gives up after superviseMaxRestarts restarts within
+// superviseWindow.
+func runSupervised() {
+	args := make([]string, 0, len(os.Args)-1)
+	for _, a := range os.Args[1:] {
+		if a == "-supervise" || a == "--supervise" {
+			continue
+		}
+		args = append(args, a)
+	}
+
+	var restarts []time.Time
+
+	for {
+		ring := &ringBuffer{}
+		err := superviseOnce(args, ring)
+		if err == nil {
+			return
+		}
+
+		now := time.Now()
+		cutoff := now.Add(-superviseWindow)
+		kept := restarts[:0]
+		for _, t := range restarts {
+			if t.After(cutoff) {
+				kept = append(kept, t)
+			}
+		}
+		restarts = append(kept, now)
+
+		if len(restarts) > superviseMaxRestarts {
+			slog.Error("Supervisor giving up after too many restarts", "window", superviseWindow, "max_restarts", superviseMaxRestarts, "last_error", err)
+			slog.Error("Captured child output", "output", ring.dump())
+			os.Stdout.Sync()
+			os.Exit(1)
+		}
+
+		slog.Warn("Reminder process exited unexpectedly, restarting", "error", err, "restart_count", len(restarts))
+		os.Stdout.Sync()
+	}
+}
+
+func superviseOnce(args []string, ring *ringBuffer) error {
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Stdout = io.MultiWriter(os.Stdout, &lineWriter{ring: ring})
+	cmd.Stderr = io.MultiWriter(os.Stderr, &lineWriter{ring: ring})
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Wait()
+}
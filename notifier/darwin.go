@@ -0,0 +1,24 @@
+//go:build darwin
+
+package notifier
+
+import (
+	"fmt"
+	"os/exec"
+
+	"fyne.io/fyne/v2"
+)
+
+// darwinNotifier is the original osascript-based behavior, unchanged.
+type darwinNotifier struct{}
+
+func init() { Default = darwinNotifier{} }
+
+func (darwinNotifier) Notify(title, body string) error {
+	return exec.Command("osascript", "-e", fmt.Sprintf(`display notification "%s" with title "%s"`, body, title)).Run()
+}
+
+func (darwinNotifier) Focus(window fyne.Window) error {
+	window.RequestFocus()
+	return exec.Command("osascript", "-e", `tell application "System Events" to set frontmost of first process whose name contains "main" to true`).Run()
+}
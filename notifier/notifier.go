@@ -0,0 +1,17 @@
+// Package notifier shows system notifications and brings a window to the
+// foreground. The implementation backing Default is chosen per-platform by
+// build tags, so move runs on darwin, linux, and windows without the
+// reminder logic knowing or caring which OS it's on.
+package notifier
+
+import "fyne.io/fyne/v2"
+
+// Notifier is a platform backend for notifications and window focus.
+type Notifier interface {
+	Notify(title, body string) error
+	Focus(window fyne.Window) error
+}
+
+// Default is the Notifier for the current platform. Each build-tagged
+// implementation file sets it from an init function.
+var Default Notifier
@@ -0,0 +1,28 @@
+//go:build windows
+
+package notifier
+
+import (
+	"fmt"
+	"os/exec"
+
+	"fyne.io/fyne/v2"
+)
+
+// windowsNotifier raises a toast via the BurntToast PowerShell module.
+// Fyne's Window interface exposes no native HWND, so there's nothing to
+// pass to user32.SetForegroundWindow; Focus falls back to Fyne's own
+// RequestFocus instead of carrying a dead syscall wrapper.
+type windowsNotifier struct{}
+
+func init() { Default = windowsNotifier{} }
+
+func (windowsNotifier) Notify(title, body string) error {
+	script := fmt.Sprintf(`New-BurntToastNotification -Text '%s','%s'`, title, body)
+	return exec.Command("powershell", "-NoProfile", "-Command", script).Run()
+}
+
+func (windowsNotifier) Focus(window fyne.Window) error {
+	window.RequestFocus()
+	return nil
+}